@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGetNodeBounded_EmptyKey(t *testing.T) {
+	ch := NewConsistentHashing(1024)
+	ch.AddNode("node1")
+	if _, err := ch.GetNodeBounded("", nil, 0); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+}
+
+func TestGetNodeBounded_EmptyRing(t *testing.T) {
+	ch := NewConsistentHashing(1024)
+	if _, err := ch.GetNodeBounded("somekey", nil, 0); err == nil {
+		t.Fatal("expected error when ring is empty")
+	}
+}
+
+func TestGetNodeBounded_SkipsFullNode(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+
+	load := map[string]int64{"a": 100, "b": 0}
+	node, err := ch.GetNodeBounded("somekey", load, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if node != "b" {
+		t.Fatalf("expected overloaded node a to be skipped, got %q", node)
+	}
+}
+
+func TestGetNodeBounded_AllNodesFull(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+
+	load := map[string]int64{"a": 100, "b": 100}
+	if _, err := ch.GetNodeBounded("somekey", load, 1); err == nil {
+		t.Fatal("expected error when every node is over capacity")
+	}
+}
+
+func TestGetNodeBounded_IncDecTracksLoadInternally(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+
+	ch.Inc("a")
+	ch.Inc("a")
+	if ch.load["a"] != 2 {
+		t.Fatalf("expected load 2 for a, got %d", ch.load["a"])
+	}
+
+	ch.Dec("a")
+	if ch.load["a"] != 1 {
+		t.Fatalf("expected load 1 for a after Dec, got %d", ch.load["a"])
+	}
+
+	ch.Dec("a")
+	ch.Dec("a")
+	if ch.load["a"] != 0 {
+		t.Fatalf("expected load to floor at 0, got %d", ch.load["a"])
+	}
+}
+
+func TestGetNodeBounded_UsesInternalLoadWhenMapIsNil(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+
+	for i := 0; i < 100; i++ {
+		ch.Inc("a")
+	}
+
+	node, err := ch.GetNodeBounded("somekey", nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if node != "b" {
+		t.Fatalf("expected internally-tracked overload on a to route to b, got %q", node)
+	}
+}
+
+func TestGetNodeBounded_DefaultFormulaFillsNodesEvenly(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c"} {
+		ch.AddNode(n)
+	}
+
+	load := map[string]int64{}
+	assigned := 0
+	for i := 0; i < 90; i++ {
+		node, err := ch.GetNodeBounded(fmt.Sprintf("key-%d", i), load, 0)
+		if err != nil {
+			t.Fatalf("unexpected error on key %d: %v", i, err)
+		}
+		load[node]++
+		assigned++
+	}
+
+	for node, l := range load {
+		if l > int64(float64(assigned)/3*ch.OverflowFactor)+1 {
+			t.Fatalf("node %q took disproportionate load %d out of %d assigned", node, l, assigned)
+		}
+	}
+}
+
+func TestRemoveNode_ClearsInternalLoad(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+
+	for i := 0; i < 50; i++ {
+		ch.Inc("a")
+	}
+	if err := ch.RemoveNode("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := ch.load["a"]; exists {
+		t.Fatal("expected load entry for removed node to be cleared")
+	}
+
+	ch.AddNode("c")
+	node, err := ch.GetNodeBounded("somekey", nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if node == "" {
+		t.Fatal("expected a node to be available once the removed node's stale load is gone")
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"maps"
+	"slices"
+)
+
+// Migration describes a contiguous ring arc, [ArcStart, ArcEnd), whose
+// ownership changes between two ring states, e.g. as reported by
+// DiffRange. ArcStart is always less than ArcEnd; a change that spans the
+// wraparound point of the keyspace is reported as two separate,
+// non-wrapping Migration records rather than one with ArcStart > ArcEnd.
+type Migration struct {
+	OldNode  string
+	NewNode  string
+	ArcStart uint64
+	ArcEnd   uint64
+}
+
+// Diff reports, for each key in sampleKeys, whether it changes ownership
+// between ch (the old ring) and other (the new ring). Only keys whose
+// owner actually changes are present in the result, so a caller planning
+// a scale-out or retirement knows exactly what to pre-copy.
+func (ch *ConsistentHashing) Diff(other *ConsistentHashing, sampleKeys []string) map[string]struct{ From, To string } {
+	changed := map[string]struct{ From, To string }{}
+
+	for _, key := range sampleKeys {
+		oldNode, err := ch.GetNode(key)
+		if err != nil {
+			continue
+		}
+		newNode, err := other.GetNode(key)
+		if err != nil {
+			continue
+		}
+		if oldNode != newNode {
+			changed[key] = struct{ From, To string }{From: oldNode, To: newNode}
+		}
+	}
+
+	return changed
+}
+
+// DiffRange walks ch's and other's sorted ring positions in tandem,
+// rather than re-hashing every sample, and streams a Migration record for
+// every arc of the keyspace [0, keyspace) whose owner differs between the
+// two rings.
+func (ch *ConsistentHashing) DiffRange(other *ConsistentHashing, keyspace uint64) <-chan Migration {
+	migrations := make(chan Migration)
+
+	ch.mu.RLock()
+	oldKeys := slices.Clone(ch.keys)
+	oldRing := maps.Clone(ch.ring)
+	ch.mu.RUnlock()
+
+	other.mu.RLock()
+	newKeys := slices.Clone(other.keys)
+	newRing := maps.Clone(other.ring)
+	other.mu.RUnlock()
+
+	go func() {
+		defer close(migrations)
+
+		boundaries := mergeBoundaries(oldKeys, newKeys, keyspace)
+		n := len(boundaries)
+		if n == 0 {
+			return
+		}
+
+		// emit reports the arc [start, end) if its owner changed. ownerPos
+		// is the ring position whose ownership applies to the whole arc:
+		// findKeyIndex's successor rule means a key only "speaks for" the
+		// positions strictly after the previous key up to and including
+		// itself, i.e. the arc (prevKey, key], not [prevKey, key).
+		emit := func(start, end, ownerPos uint64) {
+			if start >= end {
+				return
+			}
+			oldNode := ownerAt(oldKeys, oldRing, ownerPos)
+			newNode := ownerAt(newKeys, newRing, ownerPos)
+			if oldNode != newNode {
+				migrations <- Migration{
+					OldNode:  oldNode,
+					NewNode:  newNode,
+					ArcStart: start,
+					ArcEnd:   end,
+				}
+			}
+		}
+
+		for i := 1; i < n; i++ {
+			emit(boundaries[i-1]+1, boundaries[i]+1, boundaries[i])
+		}
+
+		// Positions after the last boundary, and positions up to and
+		// including the first boundary, both wrap around to boundaries[0]
+		// under the successor rule, so they're reported as two separate
+		// non-wrapping arcs that share an owner.
+		emit(boundaries[n-1]+1, keyspace, boundaries[0])
+		emit(0, boundaries[0]+1, boundaries[0])
+	}()
+
+	return migrations
+}
+
+// ownerAt returns the node owning ring position pos given a sorted set of
+// ring positions and the position->node map they were drawn from.
+func ownerAt(keys []uint64, ring map[uint64]string, pos uint64) string {
+	if len(keys) == 0 {
+		return ""
+	}
+
+	index := findKeyIndex(pos, keys)
+	if index == len(keys) {
+		index = 0
+	}
+
+	return ring[keys[index]]
+}
+
+// mergeBoundaries combines two sorted position slices into a single
+// sorted slice of unique positions within [0, keyspace).
+func mergeBoundaries(a, b []uint64, keyspace uint64) []uint64 {
+	merged := make([]uint64, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+
+	slices.Sort(merged)
+	merged = slices.Compact(merged)
+
+	for len(merged) > 0 && merged[len(merged)-1] >= keyspace {
+		merged = merged[:len(merged)-1]
+	}
+
+	return merged
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiff_NoChangeWhenRingsIdentical(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c"} {
+		ch.AddNode(n)
+	}
+
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+
+	changed := ch.Diff(ch, keys)
+	if len(changed) != 0 {
+		t.Fatalf("expected no migrations between identical rings, got %d", len(changed))
+	}
+}
+
+func TestDiff_ReportsChangedOwnership(t *testing.T) {
+	before := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c"} {
+		before.AddNode(n)
+	}
+
+	after := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		after.AddNode(n)
+	}
+
+	keys := make([]string, 500)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+
+	changed := before.Diff(after, keys)
+	if len(changed) == 0 {
+		t.Fatal("expected adding a node to move at least some keys")
+	}
+	for key, migration := range changed {
+		got, err := before.GetNode(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != migration.From {
+			t.Fatalf("key %q: From %q doesn't match old ring's owner %q", key, migration.From, got)
+		}
+	}
+}
+
+func TestDiffRange_NoMigrationsWhenRingsIdentical(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c"} {
+		ch.AddNode(n)
+	}
+
+	count := 0
+	for range ch.DiffRange(ch, 1<<20) {
+		count++
+	}
+	if count != 0 {
+		t.Fatalf("expected no migrations between identical rings, got %d", count)
+	}
+}
+
+func TestDiffRange_ReportsWellFormedArcsAfterAddingNode(t *testing.T) {
+	before := NewConsistentHashing(1 << 16)
+	for _, n := range []string{"a", "b", "c"} {
+		before.AddNode(n)
+	}
+
+	after := NewConsistentHashing(1 << 16)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		after.AddNode(n)
+	}
+
+	var total uint64
+	for migration := range before.DiffRange(after, 1<<16) {
+		if migration.OldNode == migration.NewNode {
+			t.Fatalf("expected OldNode and NewNode to differ, got %q for both", migration.OldNode)
+		}
+		if migration.ArcStart >= migration.ArcEnd {
+			t.Fatalf("expected ArcStart < ArcEnd, got [%d, %d)", migration.ArcStart, migration.ArcEnd)
+		}
+		total += migration.ArcEnd - migration.ArcStart
+	}
+
+	if total == 0 {
+		t.Fatal("expected some arcs to migrate after adding a node")
+	}
+}
+
+func TestDiffRange_NoWraparoundUnderflow(t *testing.T) {
+	const keyspace = 1 << 16
+
+	for trial := 0; trial < 30; trial++ {
+		before := NewConsistentHashing(keyspace)
+		for _, n := range []string{"a", "b", "c"} {
+			before.AddNodeWithWeight(n, trial+1)
+		}
+
+		after := NewConsistentHashing(keyspace)
+		for _, n := range []string{"a", "b", "c", "d"} {
+			after.AddNodeWithWeight(n, trial+1)
+		}
+
+		for migration := range before.DiffRange(after, keyspace) {
+			if migration.ArcStart >= migration.ArcEnd {
+				t.Fatalf("trial %d: got ArcStart (%d) >= ArcEnd (%d), which underflows ArcEnd-ArcStart",
+					trial, migration.ArcStart, migration.ArcEnd)
+			}
+		}
+	}
+}
+
+func TestDiffRange_MatchesActualOwnershipAtEveryPosition(t *testing.T) {
+	const keyspace = 256
+
+	before := NewConsistentHashing(keyspace)
+	for _, n := range []string{"a", "b", "c"} {
+		before.AddNodeWithWeight(n, 4)
+	}
+
+	after := NewConsistentHashing(keyspace)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		after.AddNodeWithWeight(n, 4)
+	}
+
+	type owners struct{ old, new string }
+	reported := make(map[uint64]owners)
+	for migration := range before.DiffRange(after, keyspace) {
+		for pos := migration.ArcStart; pos < migration.ArcEnd; pos++ {
+			if existing, ok := reported[pos]; ok {
+				t.Fatalf("position %d covered by more than one arc: %+v and %+v", pos, existing, migration)
+			}
+			reported[pos] = owners{migration.OldNode, migration.NewNode}
+		}
+	}
+
+	for pos := uint64(0); pos < keyspace; pos++ {
+		actualOld := ownerAt(before.keys, before.ring, pos)
+		actualNew := ownerAt(after.keys, after.ring, pos)
+
+		reportedOwners, changed := reported[pos]
+		if actualOld == actualNew {
+			if changed {
+				t.Fatalf("position %d: reported a migration (%+v) but ownership didn't change (owner %q)", pos, reportedOwners, actualOld)
+			}
+			continue
+		}
+
+		if !changed {
+			t.Fatalf("position %d: ownership changed from %q to %q but no migration was reported", pos, actualOld, actualNew)
+		}
+		if reportedOwners.old != actualOld || reportedOwners.new != actualNew {
+			t.Fatalf("position %d: reported migration %q->%q doesn't match actual ownership %q->%q", pos, reportedOwners.old, reportedOwners.new, actualOld, actualNew)
+		}
+	}
+}
+
+func TestDiffRange_SafeAfterConcurrentMutation(t *testing.T) {
+	before := NewConsistentHashing(1 << 16)
+	after := NewConsistentHashing(1 << 16)
+	for _, n := range []string{"a", "b", "c"} {
+		before.AddNode(n)
+		after.AddNode(n)
+	}
+	after.AddNode("d")
+
+	migrations := before.DiffRange(after, 1<<16)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			node := fmt.Sprintf("churn-%d", i)
+			before.AddNode(node)
+			before.RemoveNode(node)
+		}
+	}()
+
+	for range migrations {
+	}
+	<-done
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileBackend is a Backend that persists each key as its own file inside
+// dir, so ring topology survives process restarts.
+type FileBackend struct {
+	dir string
+}
+
+// NewFileBackend returns a FileBackend rooted at dir, creating dir if it
+// doesn't already exist.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating backend directory: %w", err)
+	}
+
+	return &FileBackend{dir: dir}, nil
+}
+
+func (f *FileBackend) Get(key []byte) ([]byte, error) {
+	data, err := os.ReadFile(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (f *FileBackend) Put(key, value []byte) error {
+	return os.WriteFile(f.path(key), value, 0o644)
+}
+
+func (f *FileBackend) path(key []byte) string {
+	return filepath.Join(f.dir, hex.EncodeToString(key))
+}
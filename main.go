@@ -8,112 +8,164 @@ import (
 	"sync"
 )
 
+// defaultReplicas is the number of virtual nodes placed on the ring for
+// each physical node added via AddNode.
+const defaultReplicas = 10
+
+// HashFunc hashes arbitrary data down to a uint64. It is pluggable so
+// callers can swap the default SHA-256 based hash for FNV, xxhash,
+// murmur, etc.
+type HashFunc func([]byte) uint64
+
 type ConsistentHashing struct {
 	totalSlots uint64
-	keys       []uint64
-	nodes      []string
-	mu         sync.RWMutex
+	keys       []uint64            // sorted ring positions
+	ring       map[uint64]string   // ring position -> physical node
+	replicas   map[string][]uint64 // physical node -> its ring positions
+	load       map[string]int64    // physical node -> load tracked via Inc/Dec
+	HashFunc   HashFunc
+
+	// OverflowFactor is the "c" constant used by GetNodeBounded: unless a
+	// capacity is passed, a node is considered full once its load reaches
+	// max(1, ceil(c * (totalLoad+1) / numNodes)).
+	OverflowFactor float64
+
+	mu sync.RWMutex
 }
 
 func NewConsistentHashing(totalSlots uint64) *ConsistentHashing {
 	return &ConsistentHashing{
-		totalSlots: totalSlots,
-		keys:       []uint64{},
-		nodes:      []string{},
+		totalSlots:     totalSlots,
+		keys:           []uint64{},
+		ring:           map[uint64]string{},
+		replicas:       map[string][]uint64{},
+		load:           map[string]int64{},
+		HashFunc:       defaultHashFunc,
+		OverflowFactor: defaultOverflowFactor,
 	}
 }
 
-// AddNode function adds a new node in the system i.e 
+// AddNode function adds a new node in the system i.e
 // hash space and node space returns the error
 func (ch *ConsistentHashing) AddNode(node string) error {
-	if len(node) == 0{
+	return ch.AddNodeWithWeight(node, defaultReplicas)
+}
+
+// AddNodeWithWeight adds a new node to the ring, placing it on `replicas`
+// virtual positions so heavier nodes (more replicas) receive a larger
+// share of the keyspace than lighter ones.
+func (ch *ConsistentHashing) AddNodeWithWeight(node string, replicas int) error {
+	if len(node) == 0 {
 		return fmt.Errorf("node can't be a empty!")
 	}
-	key := ch.hashFunc(node)
+	if replicas <= 0 {
+		return fmt.Errorf("replicas must be greater than zero")
+	}
 
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
-	index := findKeyIndex(key, ch.keys)
-	if index < len(ch.keys) && ch.keys[index] == key {
+	if _, exists := ch.replicas[node]; exists {
 		return fmt.Errorf("collision occured for node (%v)", node)
 	}
 
-	ch.keys = addKeyToIndex(key, index, ch.keys);
-	ch.nodes = addNodeToIndex(node, index, ch.nodes);
+	positions := make([]uint64, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		pos := ch.hashFunc(fmt.Sprintf("%s#%d", node, i))
+		pos = ch.placeOnRing(pos, node)
+		positions = append(positions, pos)
+	}
+	ch.replicas[node] = positions
 
 	return nil
 }
 
-// Given an item GetNode function returns node 
+// Given an item GetNode function returns node
 // i.e. index it is associated within []nodes & error
-func (ch *ConsistentHashing) GetNode(dataKey string) (string , error){
-	if len(dataKey) == 0{
-		return "", fmt.Errorf("key data cannot be empty");
+func (ch *ConsistentHashing) GetNode(dataKey string) (string, error) {
+	if len(dataKey) == 0 {
+		return "", fmt.Errorf("key data cannot be empty")
 	}
 
-	ch.mu.RLock();
-	defer ch.mu.RUnlock();
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
 
-	if len(ch.keys) == 0{
+	if len(ch.keys) == 0 {
 		return "", fmt.Errorf("hash ring is empty")
 	}
 
-	key := ch.hashFunc(dataKey);
+	key := ch.hashFunc(dataKey)
 
-	index := findKeyIndex(key, ch.keys);
+	index := findKeyIndex(key, ch.keys)
 
 	if index == len(ch.keys) {
 		index = 0
 	}
 
-	return ch.nodes[index], nil;
+	return ch.ring[ch.keys[index]], nil
 }
 
-// RemoveNode removes the node from the hash space 
-// and returns error.
+// RemoveNode removes the node and all of its virtual replicas from the
+// hash space atomically, and returns error.
 func (ch *ConsistentHashing) RemoveNode(node string) error {
-	if len(node) == 0{
-		return fmt.Errorf("node can't be a empty!");
+	if len(node) == 0 {
+		return fmt.Errorf("node can't be a empty!")
 	}
 
-	ch.mu.Lock();
-	defer ch.mu.Unlock();
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
 
-	if len(ch.keys) == 0{
+	if len(ch.keys) == 0 {
 		return fmt.Errorf("hash space is empty!")
 	}
 
-	key := ch.hashFunc(node);
-
-	index := findKeyIndex(key, ch.keys);
+	positions, exists := ch.replicas[node]
+	if !exists {
+		return fmt.Errorf("node doesn't exists or doesn't match")
+	}
 
-	if index == len(ch.keys) || ch.keys[index] != key{
-		return fmt.Errorf("node doesn't exists or doesn't match");
+	for _, pos := range positions {
+		index := findKeyIndex(pos, ch.keys)
+		if index < len(ch.keys) && ch.keys[index] == pos {
+			ch.keys = slices.Delete(ch.keys, index, index+1)
+		}
+		delete(ch.ring, pos)
 	}
+	delete(ch.replicas, node)
+	delete(ch.load, node)
 
-	ch.keys = slices.Delete(ch.keys, index, index+1);
-	ch.nodes = slices.Delete(ch.nodes, index, index+1);
-	
-	return nil;
+	return nil
 }
 
+// placeOnRing inserts node at ring position pos, resolving collisions by
+// probing forward to the next free slot so every virtual node keeps a
+// distinct position. It returns the position the node was actually
+// placed at.
+func (ch *ConsistentHashing) placeOnRing(pos uint64, node string) uint64 {
+	for {
+		index := findKeyIndex(pos, ch.keys)
+		if index < len(ch.keys) && ch.keys[index] == pos {
+			pos = (pos + 1) % ch.totalSlots
+			continue
+		}
 
-// hashFunc creates an integer equivalent of a SHA256 hash and
-// takes a modulo with the total number of slots in hash space
-func (ch *ConsistentHashing) hashFunc(key string) uint64 {
-	hash := sha256.Sum256([]byte(key))
-	value := binary.BigEndian.Uint64(hash[:8])
+		ch.keys = addKeyToIndex(pos, index, ch.keys)
+		ch.ring[pos] = node
 
-	return value % uint64(ch.totalSlots)
+		return pos
+	}
 }
 
-func addNodeToIndex(node string, index int, nodes []string) []string {
-	nodes = append(nodes, "")
-	copy(nodes[index+1:], nodes[index:])
-	nodes[index] = node
+// hashFunc creates an integer equivalent of the configured HashFunc and
+// takes a modulo with the total number of slots in hash space
+func (ch *ConsistentHashing) hashFunc(key string) uint64 {
+	return ch.HashFunc([]byte(key)) % ch.totalSlots
+}
 
-	return nodes;
+// defaultHashFunc creates an integer equivalent of a SHA256 hash.
+func defaultHashFunc(data []byte) uint64 {
+	hash := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(hash[:8])
 }
 
 func addKeyToIndex(key uint64, index int, keys []uint64) []uint64 {
@@ -11,8 +11,11 @@ func TestAddNode_Basic(t *testing.T) {
 	if err := ch.AddNode("node1"); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(ch.nodes) != 1 {
-		t.Fatalf("expected 1 node, got %d", len(ch.nodes))
+	if len(ch.replicas) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(ch.replicas))
+	}
+	if len(ch.keys) != defaultReplicas {
+		t.Fatalf("expected %d virtual nodes on the ring, got %d", defaultReplicas, len(ch.keys))
 	}
 }
 
@@ -118,8 +121,14 @@ func TestRemoveNode_Basic(t *testing.T) {
 	if err := ch.RemoveNode("node1"); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(ch.nodes) != 1 || ch.nodes[0] != "node2" {
-		t.Fatalf("expected only node2, got %v", ch.nodes)
+	if _, exists := ch.replicas["node1"]; exists {
+		t.Fatal("node1 replicas should have been torn down")
+	}
+	if _, exists := ch.replicas["node2"]; !exists {
+		t.Fatal("expected node2 to remain on the ring")
+	}
+	if len(ch.keys) != defaultReplicas {
+		t.Fatalf("expected %d virtual nodes remaining, got %d", defaultReplicas, len(ch.keys))
 	}
 }
 
@@ -260,4 +269,91 @@ func BenchmarkRemoveNode(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ch.RemoveNode(fmt.Sprintf("node-%d", i))
 	}
-}
\ No newline at end of file
+}
+
+func TestAddNodeWithWeight_MoreReplicasMoreKeys(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	if err := ch.AddNodeWithWeight("heavy", 50); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ch.AddNodeWithWeight("light", 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ch.replicas["heavy"]) != 50 {
+		t.Fatalf("expected 50 replicas for heavy node, got %d", len(ch.replicas["heavy"]))
+	}
+	if len(ch.replicas["light"]) != 5 {
+		t.Fatalf("expected 5 replicas for light node, got %d", len(ch.replicas["light"]))
+	}
+}
+
+func TestAddNodeWithWeight_InvalidReplicas(t *testing.T) {
+	ch := NewConsistentHashing(1024)
+	if err := ch.AddNodeWithWeight("node1", 0); err == nil {
+		t.Fatal("expected error for zero replicas")
+	}
+}
+
+func TestHashFunc_Pluggable(t *testing.T) {
+	ch := NewConsistentHashing(1024)
+	calls := 0
+	ch.HashFunc = func(data []byte) uint64 {
+		calls++
+		return fnv64(data)
+	}
+	if err := ch.AddNode("node1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls == 0 {
+		t.Fatal("expected custom HashFunc to be invoked")
+	}
+}
+
+// fnv64 is a tiny stand-in hash used only to prove HashFunc is pluggable.
+func fnv64(data []byte) uint64 {
+	var h uint64 = 1469598103934665603
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= 1099511628211
+	}
+	return h
+}
+
+func TestVirtualNodes_VarianceShrinksWithReplicas(t *testing.T) {
+	variance := func(replicas int) float64 {
+		ch := NewConsistentHashing(1 << 20)
+		nodes := []string{"a", "b", "c", "d"}
+		for _, n := range nodes {
+			ch.AddNodeWithWeight(n, replicas)
+		}
+
+		counts := make(map[string]int)
+		const samples = 4000
+		for i := 0; i < samples; i++ {
+			node, err := ch.GetNode(fmt.Sprintf("key-%d", i))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			counts[node]++
+		}
+
+		mean := float64(samples) / float64(len(nodes))
+		var sumSq float64
+		for _, n := range nodes {
+			diff := float64(counts[n]) - mean
+			sumSq += diff * diff
+		}
+		return sumSq / float64(len(nodes))
+	}
+
+	low := variance(1)
+	high := variance(100)
+	if high >= low {
+		t.Fatalf("expected variance to shrink with more replicas: low-replica=%v high-replica=%v", low, high)
+	}
+}
+
+func TestRemoveNode_OnlyRemapsItsOwnShare(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	assertRemoveNodeOnlyRemapsItsOwnShare(t, ch, []string{"a", "b", "c", "d"}, "b")
+}
@@ -0,0 +1,34 @@
+package main
+
+import "sync"
+
+// MemoryBackend is a Backend that keeps snapshots in memory. It is mostly
+// useful for tests and for round-tripping a ring within a single process.
+type MemoryBackend struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		data: map[string][]byte{},
+	}
+}
+
+func (m *MemoryBackend) Get(key []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.data[string(key)], nil
+}
+
+func (m *MemoryBackend) Put(key, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := make([]byte, len(value))
+	copy(stored, value)
+	m.data[string(key)] = stored
+
+	return nil
+}
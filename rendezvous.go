@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// RendezvousHashing implements Highest-Random-Weight (rendezvous) hashing:
+// it keeps zero ring state and, for each lookup, scores every node against
+// the key and returns the highest scorer. It gives perfectly uniform
+// distribution without virtual nodes and only remaps 1/N of keys on
+// membership changes, at the cost of an O(N) lookup.
+type RendezvousHashing struct {
+	nodes    map[string]struct{}
+	HashFunc HashFunc
+	mu       sync.RWMutex
+}
+
+func NewRendezvousHashing() *RendezvousHashing {
+	return &RendezvousHashing{
+		nodes:    map[string]struct{}{},
+		HashFunc: defaultHashFunc,
+	}
+}
+
+// AddNode adds node to the set of candidates considered by GetNode.
+func (rh *RendezvousHashing) AddNode(node string) error {
+	if len(node) == 0 {
+		return fmt.Errorf("node can't be a empty!")
+	}
+
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if _, exists := rh.nodes[node]; exists {
+		return fmt.Errorf("collision occured for node (%v)", node)
+	}
+
+	rh.nodes[node] = struct{}{}
+
+	return nil
+}
+
+// RemoveNode removes node from the set of candidates.
+func (rh *RendezvousHashing) RemoveNode(node string) error {
+	if len(node) == 0 {
+		return fmt.Errorf("node can't be a empty!")
+	}
+
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if _, exists := rh.nodes[node]; !exists {
+		return fmt.Errorf("node doesn't exists or doesn't match")
+	}
+
+	delete(rh.nodes, node)
+
+	return nil
+}
+
+// GetNode returns the node with the highest hash(node, key) score, i.e.
+// the node that "wins" the rendezvous for this key.
+func (rh *RendezvousHashing) GetNode(key string) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("key data cannot be empty")
+	}
+
+	rh.mu.RLock()
+	defer rh.mu.RUnlock()
+
+	if len(rh.nodes) == 0 {
+		return "", fmt.Errorf("hash ring is empty")
+	}
+
+	var winner string
+	var highScore uint64
+	first := true
+
+	for node := range rh.nodes {
+		score := rh.HashFunc([]byte(fmt.Sprintf("%s#%s", node, key)))
+		if first || score > highScore {
+			winner = node
+			highScore = score
+			first = false
+		}
+	}
+
+	return winner, nil
+}
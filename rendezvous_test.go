@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestRendezvous_AddNode_EmptyString(t *testing.T) {
+	rh := NewRendezvousHashing()
+	if err := rh.AddNode(""); err == nil {
+		t.Fatal("expected error for empty node name")
+	}
+}
+
+func TestRendezvous_AddNode_Duplicate(t *testing.T) {
+	rh := NewRendezvousHashing()
+	rh.AddNode("node1")
+	if err := rh.AddNode("node1"); err == nil {
+		t.Fatal("expected collision error for duplicate node")
+	}
+}
+
+func TestRendezvous_GetNode_EmptyRing(t *testing.T) {
+	rh := NewRendezvousHashing()
+	if _, err := rh.GetNode("somekey"); err == nil {
+		t.Fatal("expected error when ring is empty")
+	}
+}
+
+func TestRendezvous_GetNode_Consistent(t *testing.T) {
+	rh := NewRendezvousHashing()
+	for _, n := range []string{"node1", "node2", "node3"} {
+		rh.AddNode(n)
+	}
+
+	first, err := rh.GetNode("mydata")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		got, _ := rh.GetNode("mydata")
+		if got != first {
+			t.Fatalf("inconsistent result: got %q, want %q", got, first)
+		}
+	}
+}
+
+func TestRendezvous_RemoveNode_NonExistent(t *testing.T) {
+	rh := NewRendezvousHashing()
+	rh.AddNode("node1")
+	if err := rh.RemoveNode("ghost"); err == nil {
+		t.Fatal("expected error when removing non-existent node")
+	}
+}
+
+func TestRendezvous_RemoveNode_OnlyRemapsItsOwnShare(t *testing.T) {
+	rh := NewRendezvousHashing()
+	assertRemoveNodeOnlyRemapsItsOwnShare(t, rh, []string{"a", "b", "c", "d"}, "b")
+}
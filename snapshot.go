@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Backend is a minimal key/value store that Snapshot and LoadSnapshot use
+// to persist and rehydrate ring topology across process restarts.
+type Backend interface {
+	Get(key []byte) ([]byte, error)
+	Put(key, value []byte) error
+}
+
+// snapshotKey is the single key under which the ring's serialized state
+// is stored in a Backend.
+var snapshotKey = []byte("consistent-hashing/ring")
+
+// snapshotVersion is bumped whenever the binary layout written by
+// Snapshot changes in a way LoadSnapshot needs to know about.
+const snapshotVersion uint32 = 1
+
+// Snapshot serializes totalSlots and the sorted ring positions together
+// with their node names into a versioned binary blob, and stores it in b
+// under a fixed key.
+func (ch *ConsistentHashing) Snapshot(b Backend) error {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	var buf bytes.Buffer
+
+	if err := binary.Write(&buf, binary.BigEndian, snapshotVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(&buf, binary.BigEndian, ch.totalSlots); err != nil {
+		return err
+	}
+	if err := binary.Write(&buf, binary.BigEndian, uint32(len(ch.keys))); err != nil {
+		return err
+	}
+
+	for _, pos := range ch.keys {
+		node := ch.ring[pos]
+
+		if err := binary.Write(&buf, binary.BigEndian, pos); err != nil {
+			return err
+		}
+		if err := binary.Write(&buf, binary.BigEndian, uint16(len(node))); err != nil {
+			return err
+		}
+		if _, err := buf.WriteString(node); err != nil {
+			return err
+		}
+	}
+
+	return b.Put(snapshotKey, buf.Bytes())
+}
+
+// LoadSnapshot reads the blob written by Snapshot from b and replaces the
+// ring's current state with it, so callers don't have to re-add every
+// node (and re-hash every node name) on startup.
+func (ch *ConsistentHashing) LoadSnapshot(b Backend) error {
+	data, err := b.Get(snapshotKey)
+	if err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("no snapshot found")
+	}
+
+	r := bytes.NewReader(data)
+
+	var version uint32
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return fmt.Errorf("reading snapshot version: %w", err)
+	}
+	if version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", version)
+	}
+
+	var totalSlots uint64
+	if err := binary.Read(r, binary.BigEndian, &totalSlots); err != nil {
+		return fmt.Errorf("reading total slots: %w", err)
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return fmt.Errorf("reading key count: %w", err)
+	}
+
+	keys := make([]uint64, 0, count)
+	ring := make(map[uint64]string, count)
+	replicas := make(map[string][]uint64)
+
+	for i := uint32(0); i < count; i++ {
+		var pos uint64
+		if err := binary.Read(r, binary.BigEndian, &pos); err != nil {
+			return fmt.Errorf("reading ring position %d: %w", i, err)
+		}
+
+		var nameLen uint16
+		if err := binary.Read(r, binary.BigEndian, &nameLen); err != nil {
+			return fmt.Errorf("reading node name length %d: %w", i, err)
+		}
+
+		name := make([]byte, nameLen)
+		if _, err := r.Read(name); err != nil {
+			return fmt.Errorf("reading node name %d: %w", i, err)
+		}
+		node := string(name)
+
+		keys = append(keys, pos)
+		ring[pos] = node
+		replicas[node] = append(replicas[node], pos)
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	ch.totalSlots = totalSlots
+	ch.keys = keys
+	ch.ring = ring
+	ch.replicas = replicas
+	ch.load = map[string]int64{}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func ringsEqual(t *testing.T, a, b *ConsistentHashing) {
+	t.Helper()
+
+	if a.totalSlots != b.totalSlots {
+		t.Fatalf("totalSlots mismatch: %d vs %d", a.totalSlots, b.totalSlots)
+	}
+	if len(a.keys) != len(b.keys) {
+		t.Fatalf("key count mismatch: %d vs %d", len(a.keys), len(b.keys))
+	}
+	for i, pos := range a.keys {
+		if b.keys[i] != pos {
+			t.Fatalf("key %d mismatch: %d vs %d", i, pos, b.keys[i])
+		}
+		if a.ring[pos] != b.ring[pos] {
+			t.Fatalf("node at position %d mismatch: %q vs %q", pos, a.ring[pos], b.ring[pos])
+		}
+	}
+	if len(a.replicas) != len(b.replicas) {
+		t.Fatalf("node count mismatch: %d vs %d", len(a.replicas), len(b.replicas))
+	}
+}
+
+func TestSnapshot_MemoryBackendRoundTrip(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c"} {
+		ch.AddNode(n)
+	}
+
+	backend := NewMemoryBackend()
+	if err := ch.Snapshot(backend); err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+
+	restored := NewConsistentHashing(1)
+	if err := restored.LoadSnapshot(backend); err != nil {
+		t.Fatalf("unexpected error loading snapshot: %v", err)
+	}
+
+	ringsEqual(t, ch, restored)
+}
+
+func TestSnapshot_FileBackendRoundTrip(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		ch.AddNodeWithWeight(n, 3)
+	}
+
+	backend, err := NewFileBackend(filepath.Join(t.TempDir(), "ring"))
+	if err != nil {
+		t.Fatalf("unexpected error creating file backend: %v", err)
+	}
+	if err := ch.Snapshot(backend); err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+
+	restored := NewConsistentHashing(1)
+	if err := restored.LoadSnapshot(backend); err != nil {
+		t.Fatalf("unexpected error loading snapshot: %v", err)
+	}
+
+	ringsEqual(t, ch, restored)
+
+	node, err := restored.GetNode("somekey")
+	if err != nil {
+		t.Fatalf("unexpected error after restore: %v", err)
+	}
+	if node == "" {
+		t.Fatal("expected a node after restoring from snapshot")
+	}
+}
+
+func TestLoadSnapshot_NoSnapshotFound(t *testing.T) {
+	ch := NewConsistentHashing(1024)
+	if err := ch.LoadSnapshot(NewMemoryBackend()); err == nil {
+		t.Fatal("expected error loading from an empty backend")
+	}
+}
+
+func TestLoadSnapshot_ClearsStaleLoad(t *testing.T) {
+	ch := NewConsistentHashing(1 << 20)
+	ch.AddNode("a")
+	ch.AddNode("b")
+	for i := 0; i < 50; i++ {
+		ch.Inc("a")
+	}
+
+	backend := NewMemoryBackend()
+	if err := ch.Snapshot(backend); err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+
+	if err := ch.LoadSnapshot(backend); err != nil {
+		t.Fatalf("unexpected error loading snapshot: %v", err)
+	}
+
+	if len(ch.load) != 0 {
+		t.Fatalf("expected load to be reset after restoring a snapshot, got %v", ch.load)
+	}
+}
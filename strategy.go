@@ -0,0 +1,15 @@
+package main
+
+// Strategy is the common interface implemented by every hashing scheme
+// in this package, so callers can swap strategies (ring-based, rendezvous,
+// ...) without changing call sites.
+type Strategy interface {
+	GetNode(key string) (string, error)
+	AddNode(node string) error
+	RemoveNode(node string) error
+}
+
+var (
+	_ Strategy = (*ConsistentHashing)(nil)
+	_ Strategy = (*RendezvousHashing)(nil)
+)
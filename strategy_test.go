@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConsistentHashing_SatisfiesStrategy(t *testing.T) {
+	var _ Strategy = NewConsistentHashing(1024)
+}
+
+func TestRendezvous_SatisfiesStrategy(t *testing.T) {
+	var _ Strategy = NewRendezvousHashing()
+}
+
+// assertRemoveNodeOnlyRemapsItsOwnShare is shared by every Strategy
+// implementation's test suite: after adding nodes and sampling key
+// ownership, removing removedNode should only change the owner for keys
+// that were previously owned by removedNode.
+func assertRemoveNodeOnlyRemapsItsOwnShare(t *testing.T, s Strategy, nodes []string, removedNode string) {
+	t.Helper()
+
+	for _, n := range nodes {
+		if err := s.AddNode(n); err != nil {
+			t.Fatalf("unexpected error adding node %q: %v", n, err)
+		}
+	}
+
+	const samples = 500
+	before := make(map[string]string, samples)
+	for i := 0; i < samples; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		node, err := s.GetNode(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		before[key] = node
+	}
+
+	if err := s.RemoveNode(removedNode); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, prevNode := range before {
+		node, err := s.GetNode(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if prevNode != removedNode && node != prevNode {
+			t.Fatalf("key %q remapped from %q to %q despite its node not being removed", key, prevNode, node)
+		}
+	}
+}